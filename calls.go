@@ -0,0 +1,229 @@
+package twilio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/missionMeteora/twilio/twiml"
+)
+
+const (
+	callsLoc    = base + "Accounts/%s/Calls.json"
+	callsLocFmt = base + "Accounts/%s/Calls/"
+)
+
+// CallResource is the resource returned for a voice call.
+type CallResource struct {
+	Sid        string `json:"sid"`
+	AccountSid string `json:"account_sid"`
+	To         string `json:"to"`
+	From       string `json:"from"`
+	Status     string `json:"status"`
+	StartTime  string `json:"start_time"`
+	EndTime    string `json:"end_time"`
+	Duration   string `json:"duration"`
+	Direction  string `json:"direction"`
+	AnsweredBy string `json:"answered_by"`
+	Uri        string `json:"uri"`
+	Message    string `json:"message"`
+}
+
+// Call places a new voice call from 'from' to 'to', executing tw once
+// answered. The TwiML document is inlined into the request rather than
+// fetched from a hosted Url, so callers don't need to stand up their own
+// endpoint.
+func (c *Client) Call(ctx context.Context, from, to string, tw *twiml.Response) (*CallResource, error) {
+	v := url.Values{}
+	v.Set("From", from)
+	v.Set("To", to)
+	v.Set("Twiml", tw.String())
+
+	return c.callRequest(ctx, c.getUrl(callsLoc), v)
+}
+
+// CallWithURL places a new voice call from 'from' to 'to', fetching the
+// TwiML to execute from a caller-hosted endpoint once the call is
+// answered.
+func (c *Client) CallWithURL(ctx context.Context, from, to, twimlURL string) (*CallResource, error) {
+	v := url.Values{}
+	v.Set("From", from)
+	v.Set("To", to)
+	v.Set("Url", twimlURL)
+
+	return c.callRequest(ctx, c.getUrl(callsLoc), v)
+}
+
+// GetCall fetches a single call resource by SID.
+func (c *Client) GetCall(ctx context.Context, sid string) (*CallResource, error) {
+	resp, err := c.getForm(ctx, c.getUrl(callsLocFmt)+sid+".json")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var r CallResource
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return nil, err
+	}
+
+	if r.Message != "" {
+		return nil, errors.New(r.Message)
+	}
+
+	return &r, nil
+}
+
+// CallFilter narrows a ListCalls call. Zero values are omitted from the
+// request.
+type CallFilter struct {
+	To       string
+	From     string
+	Status   string
+	PageSize int
+}
+
+func (f CallFilter) values() url.Values {
+	v := url.Values{}
+	if f.To != "" {
+		v.Set("To", f.To)
+	}
+	if f.From != "" {
+		v.Set("From", f.From)
+	}
+	if f.Status != "" {
+		v.Set("Status", f.Status)
+	}
+	if f.PageSize > 0 {
+		v.Set("PageSize", strconv.Itoa(f.PageSize))
+	}
+
+	return v
+}
+
+// callsPage is Twilio's list envelope for the Calls resource.
+type callsPage struct {
+	Calls       []*CallResource `json:"calls"`
+	NextPageURI string          `json:"next_page_uri"`
+}
+
+// CallOrError is a single item from the channel returned by ListCalls:
+// exactly one of Call or Err is set.
+type CallOrError struct {
+	Call *CallResource
+	Err  error
+}
+
+// ListCalls streams every call matching filter, following Twilio's
+// next_page_uri until the list is exhausted, over a channel so callers on
+// pre-1.23 Go toolchains don't need range-over-func/iter support (see
+// ListMessages). The channel is closed once the list is exhausted, an
+// error occurs (the last item sent), or ctx is done.
+func (c *Client) ListCalls(ctx context.Context, filter CallFilter) <-chan CallOrError {
+	ch := make(chan CallOrError)
+
+	go func() {
+		defer close(ch)
+
+		next := c.getUrl(callsLoc) + "?" + filter.values().Encode()
+
+		for next != "" {
+			resp, err := c.getForm(ctx, next)
+			if err != nil {
+				sendCall(ctx, ch, CallOrError{Err: err})
+				return
+			}
+
+			var page callsPage
+			err = json.NewDecoder(resp.Body).Decode(&page)
+			resp.Body.Close()
+			if err != nil {
+				sendCall(ctx, ch, CallOrError{Err: err})
+				return
+			}
+
+			for _, call := range page.Calls {
+				if !sendCall(ctx, ch, CallOrError{Call: call}) {
+					return
+				}
+			}
+
+			next = ""
+			if page.NextPageURI != "" {
+				next = apiHost + page.NextPageURI
+			}
+		}
+	}()
+
+	return ch
+}
+
+// sendCall delivers item to ch, reporting false (without sending) if ctx
+// is done first.
+func sendCall(ctx context.Context, ch chan<- CallOrError, item CallOrError) bool {
+	select {
+	case ch <- item:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// CallUpdate describes a change to apply to a live or in-progress call via
+// UpdateCall.
+type CallUpdate struct {
+	// Status hangs up the call when set to "completed", or stops a
+	// ringing call when set to "canceled".
+	Status string
+	// Url redirects the live call to fetch new TwiML from a hosted
+	// endpoint. Twiml inlines a new document instead; set at most one.
+	Url    string
+	Twiml  *twiml.Response
+	Method string
+}
+
+// UpdateCall redirects or hangs up an in-progress call.
+func (c *Client) UpdateCall(ctx context.Context, sid string, update CallUpdate) (*CallResource, error) {
+	v := url.Values{}
+	if update.Status != "" {
+		v.Set("Status", update.Status)
+	}
+	if update.Url != "" {
+		v.Set("Url", update.Url)
+	}
+	if update.Twiml != nil {
+		v.Set("Twiml", update.Twiml.String())
+	}
+	if update.Method != "" {
+		v.Set("Method", update.Method)
+	}
+
+	return c.callRequest(ctx, c.getUrl(callsLocFmt)+sid+".json", v)
+}
+
+// HangupCall ends an in-progress or ringing call.
+func (c *Client) HangupCall(ctx context.Context, sid string) (*CallResource, error) {
+	return c.UpdateCall(ctx, sid, CallUpdate{Status: "completed"})
+}
+
+func (c *Client) callRequest(ctx context.Context, loc string, v url.Values) (*CallResource, error) {
+	resp, err := c.postForm(ctx, loc, strings.NewReader(v.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var r CallResource
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return nil, err
+	}
+
+	if r.Message != "" {
+		return nil, errors.New(r.Message)
+	}
+
+	return &r, nil
+}
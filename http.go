@@ -0,0 +1,113 @@
+package twilio
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// maxRetries bounds the number of additional attempts made after an
+	// initial request that comes back 429 or 5xx.
+	maxRetries = 4
+
+	// baseRetryDelay and maxRetryDelay bound the exponential backoff used
+	// between retries when Twilio doesn't send a Retry-After header.
+	baseRetryDelay = 250 * time.Millisecond
+	maxRetryDelay  = 4 * time.Second
+)
+
+// postForm issues a POST with the given x-www-form-urlencoded body,
+// authenticated and retried like any other API request.
+func (c *Client) postForm(ctx context.Context, url string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	return c.do(req)
+}
+
+// getForm issues an authenticated, retried GET request.
+func (c *Client) getForm(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.do(req)
+}
+
+// do sets basic auth from the client's credentials and runs req through
+// c.httpClient, automatically retrying 429 and 5xx responses with
+// exponential backoff and jitter, honoring any Retry-After header Twilio
+// sends along with the failure.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	req.SetBasicAuth(c.key, c.token)
+
+	hc := c.httpClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				req.Body = body
+			}
+		}
+
+		resp, err := hc.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		if attempt >= maxRetries || !shouldRetry(resp.StatusCode) {
+			return resp, nil
+		}
+
+		wait := retryDelay(resp, attempt)
+		resp.Body.Close()
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+func shouldRetry(status int) bool {
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+// retryDelay honors a Retry-After header (either delta-seconds or an
+// HTTP-date) when present, otherwise falls back to full-jitter exponential
+// backoff.
+func retryDelay(resp *http.Response, attempt int) time.Duration {
+	if ra := resp.Header.Get("Retry-After"); ra != "" {
+		if secs, err := strconv.Atoi(ra); err == nil {
+			return time.Duration(secs) * time.Second
+		}
+		if t, err := http.ParseTime(ra); err == nil {
+			if d := time.Until(t); d > 0 {
+				return d
+			}
+		}
+	}
+
+	d := baseRetryDelay * time.Duration(1<<uint(attempt))
+	if d > maxRetryDelay {
+		d = maxRetryDelay
+	}
+
+	return time.Duration(rand.Int63n(int64(d))) + 1
+}
@@ -0,0 +1,172 @@
+package twilio
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDoRetriesOn429HonoringRetryAfter(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c := &Client{key: "AC_test", token: "token", httpClient: srv.Client()}
+	resp, err := c.getForm(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatalf("getForm() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 2 {
+		t.Errorf("attempts = %d, want 2", got)
+	}
+}
+
+func TestDoRetriesOn5xxWithBackoff(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c := &Client{key: "AC_test", token: "token", httpClient: srv.Client()}
+	resp, err := c.getForm(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatalf("getForm() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 2 {
+		t.Errorf("attempts = %d, want 2", got)
+	}
+}
+
+func TestDoDoesNotRetryNon429ClientErrors(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	c := &Client{key: "AC_test", token: "token", httpClient: srv.Client()}
+	resp, err := c.getForm(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatalf("getForm() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 1 {
+		t.Errorf("attempts = %d, want 1 (no retry on plain 4xx)", got)
+	}
+}
+
+func TestDoReplaysPostBodyOnRetry(t *testing.T) {
+	const wantBody = "To=%2B15551234567&From=%2B15557654321&Body=hi"
+
+	var mu sync.Mutex
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("reading request body: %v", err)
+		}
+
+		mu.Lock()
+		bodies = append(bodies, string(b))
+		n := len(bodies)
+		mu.Unlock()
+
+		if n == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c := &Client{key: "AC_test", token: "token", httpClient: srv.Client()}
+	resp, err := c.postForm(context.Background(), srv.URL, strings.NewReader(wantBody))
+	if err != nil {
+		t.Fatalf("postForm() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(bodies) != 2 {
+		t.Fatalf("server saw %d requests, want 2", len(bodies))
+	}
+	for i, b := range bodies {
+		if b != wantBody {
+			t.Errorf("attempt %d body = %q, want %q", i+1, b, wantBody)
+		}
+	}
+}
+
+func TestRetryDelayHonorsRetryAfterSeconds(t *testing.T) {
+	resp := &http.Response{Header: http.Header{"Retry-After": {"2"}}}
+
+	got := retryDelay(resp, 0)
+	if got != 2*time.Second {
+		t.Errorf("retryDelay() = %v, want %v", got, 2*time.Second)
+	}
+}
+
+func TestRetryDelayHonorsRetryAfterHTTPDate(t *testing.T) {
+	future := time.Now().Add(3 * time.Second).UTC()
+	resp := &http.Response{Header: http.Header{"Retry-After": {future.Format(http.TimeFormat)}}}
+
+	got := retryDelay(resp, 0)
+	if got <= 0 || got > 3*time.Second {
+		t.Errorf("retryDelay() = %v, want (0, %v]", got, 3*time.Second)
+	}
+}
+
+func TestRetryDelayIgnoresPastRetryAfterDate(t *testing.T) {
+	past := time.Now().Add(-3 * time.Second).UTC()
+	resp := &http.Response{Header: http.Header{"Retry-After": {past.Format(http.TimeFormat)}}}
+
+	// A Retry-After date in the past must not produce a negative/zero
+	// sleep; fall back to backoff instead.
+	got := retryDelay(resp, 0)
+	if got <= 0 || got > maxRetryDelay {
+		t.Errorf("retryDelay() = %v, want (0, %v]", got, maxRetryDelay)
+	}
+}
+
+func TestRetryDelayBackoffIsCapped(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+
+	got := retryDelay(resp, 10)
+	if got <= 0 || got > maxRetryDelay {
+		t.Errorf("retryDelay() = %v, want (0, %v]", got, maxRetryDelay)
+	}
+}
@@ -0,0 +1,152 @@
+package twilio
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// apiHost is prepended to the (host-relative) next_page_uri Twilio embeds
+// in a list response.
+const apiHost = "https://api.twilio.com"
+
+// MessageFilter narrows a ListMessages call. Zero values are omitted from
+// the request, matching all messages for that field.
+type MessageFilter struct {
+	To   string
+	From string
+
+	// DateSentAfter and DateSentBefore bound the messages returned to
+	// those sent on or after/before the given day, corresponding to
+	// Twilio's DateSent>= and DateSent<= query parameters.
+	DateSentAfter  time.Time
+	DateSentBefore time.Time
+
+	// PageSize caps the number of messages fetched per page. Twilio
+	// defaults to 50 and caps at 1000 when left zero.
+	PageSize int
+}
+
+func (f MessageFilter) values() url.Values {
+	v := url.Values{}
+	if f.To != "" {
+		v.Set("To", f.To)
+	}
+	if f.From != "" {
+		v.Set("From", f.From)
+	}
+	if !f.DateSentAfter.IsZero() {
+		v.Set("DateSent>=", f.DateSentAfter.Format("2006-01-02"))
+	}
+	if !f.DateSentBefore.IsZero() {
+		v.Set("DateSent<=", f.DateSentBefore.Format("2006-01-02"))
+	}
+	if f.PageSize > 0 {
+		v.Set("PageSize", strconv.Itoa(f.PageSize))
+	}
+
+	return v
+}
+
+// messagesPage is Twilio's list envelope for the Messages resource.
+type messagesPage struct {
+	Messages    []*SMS `json:"messages"`
+	NextPageURI string `json:"next_page_uri"`
+}
+
+// MessageOrError is a single item from the channel returned by
+// ListMessages: exactly one of Message or Err is set.
+type MessageOrError struct {
+	Message *SMS
+	Err     error
+}
+
+// ListMessages streams every message matching filter, following Twilio's
+// next_page_uri until the list is exhausted, over a channel so callers on
+// pre-1.23 Go toolchains don't need range-over-func/iter support. The
+// channel is closed once the list is exhausted, an error occurs (the
+// last item sent), or ctx is done. Canceling ctx is also how a caller
+// abandons iteration early.
+func (c *Client) ListMessages(ctx context.Context, filter MessageFilter) <-chan MessageOrError {
+	ch := make(chan MessageOrError)
+
+	go func() {
+		defer close(ch)
+
+		next := c.getUrl(messagesLoc) + "?" + filter.values().Encode()
+
+		for next != "" {
+			resp, err := c.getForm(ctx, next)
+			if err != nil {
+				sendMessage(ctx, ch, MessageOrError{Err: err})
+				return
+			}
+
+			var page messagesPage
+			err = json.NewDecoder(resp.Body).Decode(&page)
+			resp.Body.Close()
+			if err != nil {
+				sendMessage(ctx, ch, MessageOrError{Err: err})
+				return
+			}
+
+			for _, m := range page.Messages {
+				if !sendMessage(ctx, ch, MessageOrError{Message: m}) {
+					return
+				}
+			}
+
+			next = ""
+			if page.NextPageURI != "" {
+				next = apiHost + page.NextPageURI
+			}
+		}
+	}()
+
+	return ch
+}
+
+// sendMessage delivers item to ch, reporting false (without sending) if
+// ctx is done first.
+func sendMessage(ctx context.Context, ch chan<- MessageOrError, item MessageOrError) bool {
+	select {
+	case ch <- item:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// GetThread retrieves the full SMS thread between two phone numbers,
+// newest first.
+func (c *Client) GetThread(host, client string) ([]*SMS, error) {
+	return c.GetThreadContext(context.Background(), host, client)
+}
+
+// GetThreadContext is GetThread with caller-supplied cancellation/deadlines.
+func (c *Client) GetThreadContext(ctx context.Context, host, client string) ([]*SMS, error) {
+	var messages []*SMS
+
+	// A thread is the messages sent in each direction between the two
+	// numbers, merged and re-sorted.
+	for _, pair := range [2][2]string{{host, client}, {client, host}} {
+		for item := range c.ListMessages(ctx, MessageFilter{From: pair[0], To: pair[1]}) {
+			if item.Err != nil {
+				return nil, item.Err
+			}
+			messages = append(messages, item.Message)
+		}
+	}
+
+	// Stable so that messages tied on DateSent (easy to hit once both
+	// directions are paginated, streaming sources) keep the relative
+	// order they were received in.
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].DateSentAsTime().Unix() > messages[j].DateSentAsTime().Unix()
+	})
+
+	return messages, nil
+}
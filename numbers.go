@@ -0,0 +1,235 @@
+package twilio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+)
+
+const (
+	availableNumbersLoc = base + "Accounts/%s/AvailablePhoneNumbers/"
+	incomingNumbersLoc  = base + "Accounts/%s/IncomingPhoneNumbers.json"
+)
+
+// NumberType is a category of phone number Twilio can search and
+// provision, as distinguished by the AvailablePhoneNumbers API.
+type NumberType string
+
+const (
+	Local    NumberType = "Local"
+	TollFree NumberType = "TollFree"
+	Mobile   NumberType = "Mobile"
+)
+
+// NumberSearch narrows a SearchAvailableNumbers call. Country defaults to
+// "US" and Type defaults to Local when left zero.
+type NumberSearch struct {
+	// Country is the ISO 3166-1 alpha-2 country code to search, e.g. "US"
+	// or "GB".
+	Country string
+	Type    NumberType
+
+	AreaCode string
+	Contains string
+	// Region is a state/province, e.g. "TX".
+	Region string
+
+	VoiceEnabled bool
+	SmsEnabled   bool
+	MmsEnabled   bool
+	FaxEnabled   bool
+}
+
+func (s NumberSearch) country() string {
+	if s.Country == "" {
+		return "US"
+	}
+	return s.Country
+}
+
+func (s NumberSearch) numberType() NumberType {
+	if s.Type == "" {
+		return Local
+	}
+	return s.Type
+}
+
+func (s NumberSearch) values() url.Values {
+	v := url.Values{}
+	if s.AreaCode != "" {
+		v.Set("AreaCode", s.AreaCode)
+	}
+	if s.Contains != "" {
+		v.Set("Contains", s.Contains)
+	}
+	if s.Region != "" {
+		v.Set("InRegion", s.Region)
+	}
+	if s.VoiceEnabled {
+		v.Set("VoiceEnabled", "true")
+	}
+	if s.SmsEnabled {
+		v.Set("SmsEnabled", "true")
+	}
+	if s.MmsEnabled {
+		v.Set("MmsEnabled", "true")
+	}
+	if s.FaxEnabled {
+		v.Set("FaxEnabled", "true")
+	}
+
+	return v
+}
+
+// SearchAvailableNumbers lists phone numbers available for purchase
+// matching the given search criteria, covering any country and number
+// type the AvailablePhoneNumbers endpoint supports.
+func (c *Client) SearchAvailableNumbers(ctx context.Context, search NumberSearch) ([]AvailableNumber, error) {
+	loc := availableNumbersLoc + search.country() + "/" + string(search.numberType()) + ".json"
+
+	resp, err := c.getForm(ctx, c.getUrl(loc)+"?"+search.values().Encode())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var r Numbers
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return nil, err
+	}
+
+	// If response object has a key of 'message', this means that
+	// some sort of error has occured. Create a new error with the
+	// provided message and return
+	if r.Message != "" {
+		return nil, errors.New(r.Message)
+	}
+
+	return r.Numbers, nil
+}
+
+type Numbers struct {
+	Numbers []AvailableNumber `json:"available_phone_numbers"`
+	Message string            `json:"message"`
+}
+
+type AvailableNumber struct {
+	Sid                  string      `json:"sid"`
+	AccountSid           string      `json:"account_sid"`
+	FriendlyName         string      `json:"friendly_name"`
+	PhoneNumber          string      `json:"phone_number"`
+	VoiceURL             string      `json:"voice_url"`
+	VoiceMethod          string      `json:"voice_method"`
+	VoiceFallbackURL     interface{} `json:"voice_fallback_url"`
+	VoiceFallbackMethod  string      `json:"voice_fallback_method"`
+	StatusCallback       interface{} `json:"status_callback"`
+	StatusCallbackMethod interface{} `json:"status_callback_method"`
+	VoiceCallerIDLookup  interface{} `json:"voice_caller_id_lookup"`
+	VoiceApplicationSid  interface{} `json:"voice_application_sid"`
+	DateCreated          string      `json:"date_created"`
+	DateUpdated          string      `json:"date_updated"`
+	SmsURL               interface{} `json:"sms_url"`
+	SmsMethod            string      `json:"sms_method"`
+	SmsFallbackURL       interface{} `json:"sms_fallback_url"`
+	SmsFallbackMethod    string      `json:"sms_fallback_method"`
+	SmsApplicationSid    string      `json:"sms_application_sid"`
+	Capabilities         struct {
+		Voice bool `json:"voice"`
+		Sms   bool `json:"sms"`
+		Mms   bool `json:"mms"`
+	} `json:"capabilities"`
+	Beta       bool   `json:"beta"`
+	APIVersion string `json:"api_version"`
+	URI        string `json:"uri"`
+	Message    string `json:"message"`
+}
+
+// IncomingPhoneNumber is the resource created by BuyNumber. It shares its
+// shape with AvailableNumber, which is what the IncomingPhoneNumbers
+// endpoint actually returns.
+type IncomingPhoneNumber = AvailableNumber
+
+// NumberPurchase describes a phone number to provision on the account via
+// BuyNumber.
+type NumberPurchase struct {
+	// PhoneNumber is one of the E.164 numbers returned by
+	// SearchAvailableNumbers.
+	PhoneNumber string
+
+	FriendlyName      string
+	SmsUrl            string
+	VoiceUrl          string
+	StatusCallbackUrl string
+}
+
+// BuyNumber provisions purchase.PhoneNumber on the account, wiring up the
+// given SMS/voice/status-callback URLs.
+func (c *Client) BuyNumber(ctx context.Context, purchase NumberPurchase) (*IncomingPhoneNumber, error) {
+	v := url.Values{}
+	v.Set("PhoneNumber", purchase.PhoneNumber)
+	if purchase.FriendlyName != "" {
+		v.Set("FriendlyName", purchase.FriendlyName)
+	}
+	if purchase.SmsUrl != "" {
+		v.Set("SmsUrl", purchase.SmsUrl)
+	}
+	if purchase.VoiceUrl != "" {
+		v.Set("VoiceUrl", purchase.VoiceUrl)
+	}
+	if purchase.StatusCallbackUrl != "" {
+		v.Set("StatusCallback", purchase.StatusCallbackUrl)
+	}
+
+	resp, err := c.postForm(ctx, c.getUrl(incomingNumbersLoc), strings.NewReader(v.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var number IncomingPhoneNumber
+	if err := json.NewDecoder(resp.Body).Decode(&number); err != nil {
+		return nil, err
+	}
+
+	if number.Message != "" {
+		return nil, errors.New(number.Message)
+	}
+
+	return &number, nil
+}
+
+// AddNumber finds the first available US/TX local SMS-enabled number and
+// buys it, returning its phone number. It is a thin backwards-compatible
+// wrapper around SearchAvailableNumbers and BuyNumber; new integrations
+// should call those directly for control over country, number type, and
+// capabilities.
+func (c *Client) AddNumber() (string, error) {
+	return c.AddNumberContext(context.Background())
+}
+
+// AddNumberContext is AddNumber with caller-supplied cancellation/deadlines.
+func (c *Client) AddNumberContext(ctx context.Context) (string, error) {
+	numbers, err := c.SearchAvailableNumbers(ctx, NumberSearch{
+		Region:     "TX",
+		SmsEnabled: true,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if len(numbers) == 0 || numbers[0].PhoneNumber == "" {
+		return "", errors.New("No numbers available")
+	}
+
+	number, err := c.BuyNumber(ctx, NumberPurchase{
+		PhoneNumber: numbers[0].PhoneNumber,
+		SmsUrl:      c.responseTemplate,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return number.PhoneNumber, nil
+}
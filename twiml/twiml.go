@@ -0,0 +1,206 @@
+// Package twiml builds TwiML documents: the small XML dialect Twilio's
+// Voice API uses to script a call. A Response is an ordered list of
+// Verbs; render it with String() and either pass it inline to
+// Client.Call or serve it from a webhook.
+package twiml
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Verb is a single TwiML instruction that knows how to render itself as
+// XML.
+type Verb interface {
+	render(*strings.Builder)
+}
+
+// Response is a TwiML document: the verbs Twilio will execute in order.
+type Response struct {
+	Verbs []Verb
+}
+
+// NewResponse builds a Response from the given verbs, in execution order.
+func NewResponse(verbs ...Verb) *Response {
+	return &Response{Verbs: verbs}
+}
+
+// String renders the document as an XML string, suitable for an
+// application/xml webhook reply or for Client.Call's inline TwiML
+// parameter.
+func (r *Response) String() string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?><Response>`)
+	for _, v := range r.Verbs {
+		v.render(&b)
+	}
+	b.WriteString(`</Response>`)
+	return b.String()
+}
+
+// Say speaks Text to the caller using Twilio's text-to-speech engine.
+type Say struct {
+	Text     string
+	Voice    string // e.g. "alice", "man", "woman"
+	Language string
+	Loop     int
+}
+
+func (s Say) render(b *strings.Builder) {
+	b.WriteString("<Say")
+	writeAttr(b, "voice", s.Voice)
+	writeAttr(b, "language", s.Language)
+	if s.Loop > 0 {
+		writeAttr(b, "loop", strconv.Itoa(s.Loop))
+	}
+	b.WriteString(">")
+	b.WriteString(escape(s.Text))
+	b.WriteString("</Say>")
+}
+
+// Play plays an audio file at URL to the caller.
+type Play struct {
+	URL  string
+	Loop int
+}
+
+func (p Play) render(b *strings.Builder) {
+	b.WriteString("<Play")
+	if p.Loop > 0 {
+		writeAttr(b, "loop", strconv.Itoa(p.Loop))
+	}
+	b.WriteString(">")
+	b.WriteString(escape(p.URL))
+	b.WriteString("</Play>")
+}
+
+// Pause waits silently for Length seconds (default 1).
+type Pause struct {
+	Length int
+}
+
+func (p Pause) render(b *strings.Builder) {
+	b.WriteString("<Pause")
+	if p.Length > 0 {
+		writeAttr(b, "length", strconv.Itoa(p.Length))
+	}
+	b.WriteString("/>")
+}
+
+// Gather collects digits (and/or speech) from the caller, then requests
+// Action. Any nested Verbs (typically Say/Play) are played while Twilio
+// waits for input.
+type Gather struct {
+	Input       string // "dtmf", "speech", or "dtmf speech"
+	NumDigits   int
+	FinishOnKey string
+	Timeout     int
+	Action      string
+	Method      string
+	Verbs       []Verb
+}
+
+func (g Gather) render(b *strings.Builder) {
+	b.WriteString("<Gather")
+	writeAttr(b, "input", g.Input)
+	if g.NumDigits > 0 {
+		writeAttr(b, "numDigits", strconv.Itoa(g.NumDigits))
+	}
+	writeAttr(b, "finishOnKey", g.FinishOnKey)
+	if g.Timeout > 0 {
+		writeAttr(b, "timeout", strconv.Itoa(g.Timeout))
+	}
+	writeAttr(b, "action", g.Action)
+	writeAttr(b, "method", g.Method)
+
+	if len(g.Verbs) == 0 {
+		b.WriteString("/>")
+		return
+	}
+
+	b.WriteString(">")
+	for _, v := range g.Verbs {
+		v.render(b)
+	}
+	b.WriteString("</Gather>")
+}
+
+// Dial connects the caller to Number (or another dial-able endpoint).
+type Dial struct {
+	Number   string
+	CallerID string
+	Timeout  int
+	Action   string
+	Method   string
+	// Record controls call recording, e.g. "record-from-answer".
+	Record string
+}
+
+func (d Dial) render(b *strings.Builder) {
+	b.WriteString("<Dial")
+	writeAttr(b, "callerId", d.CallerID)
+	if d.Timeout > 0 {
+		writeAttr(b, "timeout", strconv.Itoa(d.Timeout))
+	}
+	writeAttr(b, "action", d.Action)
+	writeAttr(b, "method", d.Method)
+	writeAttr(b, "record", d.Record)
+	b.WriteString(">")
+	b.WriteString(escape(d.Number))
+	b.WriteString("</Dial>")
+}
+
+// Record records the caller's voice, then requests Action once they hang
+// up, press a digit, or MaxLength is reached.
+type Record struct {
+	MaxLength   int
+	Timeout     int
+	FinishOnKey string
+	// PlayBeep overrides whether Twilio plays a beep before recording
+	// starts (Twilio defaults to true). Leave nil to omit.
+	PlayBeep           *bool
+	TranscribeCallback string
+	Action             string
+	Method             string
+}
+
+func (r Record) render(b *strings.Builder) {
+	b.WriteString("<Record")
+	if r.MaxLength > 0 {
+		writeAttr(b, "maxLength", strconv.Itoa(r.MaxLength))
+	}
+	if r.Timeout > 0 {
+		writeAttr(b, "timeout", strconv.Itoa(r.Timeout))
+	}
+	writeAttr(b, "finishOnKey", r.FinishOnKey)
+	if r.PlayBeep != nil {
+		writeAttr(b, "playBeep", strconv.FormatBool(*r.PlayBeep))
+	}
+	writeAttr(b, "transcribeCallback", r.TranscribeCallback)
+	writeAttr(b, "action", r.Action)
+	writeAttr(b, "method", r.Method)
+	b.WriteString("/>")
+}
+
+func writeAttr(b *strings.Builder, name, value string) {
+	if value == "" {
+		return
+	}
+	b.WriteByte(' ')
+	b.WriteString(name)
+	b.WriteString(`="`)
+	b.WriteString(escape(value))
+	b.WriteString(`"`)
+}
+
+func escape(s string) string {
+	return xmlEscaper.Replace(s)
+}
+
+var xmlEscaper = strings.NewReplacer(
+	"&", "&amp;",
+	"<", "&lt;",
+	">", "&gt;",
+	`"`, "&quot;",
+	"'", "&apos;",
+)
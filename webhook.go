@@ -0,0 +1,186 @@
+package twilio
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// InboundSMS is the set of form parameters Twilio posts to a messaging
+// webhook when an SMS/MMS is received.
+type InboundSMS struct {
+	MessageSid  string
+	AccountSid  string
+	From        string
+	To          string
+	Body        string
+	NumMedia    string
+	MediaUrls   []string
+	FromCity    string
+	FromState   string
+	FromZip     string
+	FromCountry string
+}
+
+// StatusCallback is the set of form parameters Twilio posts to a status
+// callback URL as a message moves through its delivery lifecycle.
+type StatusCallback struct {
+	MessageSid    string
+	MessageStatus string
+	To            string
+	From          string
+	ErrorCode     string
+}
+
+// WebhookHandler implements http.Handler, validating Twilio's
+// X-Twilio-Signature header and dispatching parsed inbound messages and
+// status callbacks to the registered callbacks.
+//
+// AuthToken must be the same auth token used to construct the Client for
+// this account.
+type WebhookHandler struct {
+	AuthToken string
+
+	// OnInboundSMS is called for every validly signed POST that does not
+	// carry a MessageStatus parameter, treating it as an inbound message;
+	// the presence of a Body parameter is not checked. May be nil.
+	OnInboundSMS func(*InboundSMS)
+	// OnStatusCallback is called for POSTs that carry a MessageStatus
+	// parameter. May be nil.
+	OnStatusCallback func(*StatusCallback)
+}
+
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+
+	if !h.validSignature(r) {
+		http.Error(w, "invalid signature", http.StatusForbidden)
+		return
+	}
+
+	form := r.PostForm
+	if form.Get("MessageStatus") != "" {
+		if h.OnStatusCallback != nil {
+			h.OnStatusCallback(&StatusCallback{
+				MessageSid:    form.Get("MessageSid"),
+				MessageStatus: form.Get("MessageStatus"),
+				To:            form.Get("To"),
+				From:          form.Get("From"),
+				ErrorCode:     form.Get("ErrorCode"),
+			})
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if h.OnInboundSMS != nil {
+		h.OnInboundSMS(&InboundSMS{
+			MessageSid:  form.Get("MessageSid"),
+			AccountSid:  form.Get("AccountSid"),
+			From:        form.Get("From"),
+			To:          form.Get("To"),
+			Body:        form.Get("Body"),
+			NumMedia:    form.Get("NumMedia"),
+			MediaUrls:   mediaURLs(form),
+			FromCity:    form.Get("FromCity"),
+			FromState:   form.Get("FromState"),
+			FromZip:     form.Get("FromZip"),
+			FromCountry: form.Get("FromCountry"),
+		})
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// mediaURLs collects every MediaUrl<n> parameter Twilio attaches to an
+// MMS, from 0 up to (but not including) NumMedia. Each attachment gets
+// its own independent key, so a plain form["MediaUrl0"] lookup would only
+// ever see the first one.
+func mediaURLs(form url.Values) []string {
+	n, _ := strconv.Atoi(form.Get("NumMedia"))
+	if n <= 0 {
+		return nil
+	}
+
+	urls := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		urls = append(urls, form.Get("MediaUrl"+strconv.Itoa(i)))
+	}
+
+	return urls
+}
+
+// validSignature recomputes Twilio's request signature and compares it in
+// constant time against the X-Twilio-Signature header.
+//
+// Twilio's algorithm: take the full URL of the request, append each POST
+// parameter's key immediately followed by its value (no separators), with
+// parameters sorted by key; HMAC-SHA1 the result using the auth token as
+// the key, then base64-encode it.
+func (h *WebhookHandler) validSignature(r *http.Request) bool {
+	sig := r.Header.Get("X-Twilio-Signature")
+	if sig == "" {
+		return false
+	}
+
+	var buf strings.Builder
+	buf.WriteString(requestURL(r))
+
+	keys := make([]string, 0, len(r.PostForm))
+	for k := range r.PostForm {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		for _, v := range r.PostForm[k] {
+			buf.WriteString(k)
+			buf.WriteString(v)
+		}
+	}
+
+	mac := hmac.New(sha1.New, []byte(h.AuthToken))
+	mac.Write([]byte(buf.String()))
+	expected := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	return hmac.Equal([]byte(expected), []byte(sig))
+}
+
+// requestURL reconstructs the full URL Twilio would have used to reach
+// this handler, as seen from behind whatever's terminating TLS.
+func requestURL(r *http.Request) string {
+	scheme := "https"
+	if r.TLS == nil && r.Header.Get("X-Forwarded-Proto") != "https" {
+		scheme = "http"
+	}
+
+	return fmt.Sprintf("%s://%s%s", scheme, r.Host, r.URL.RequestURI())
+}
+
+// ReplyTwiML writes a minimal TwiML response containing a single
+// <Message> verb, as Twilio's webhook model expects when a handler wants
+// to answer inline rather than via a follow-up API call.
+func ReplyTwiML(w http.ResponseWriter, message string) {
+	w.Header().Set("Content-Type", "application/xml")
+	fmt.Fprintf(w, "<?xml version=\"1.0\" encoding=\"UTF-8\"?><Response><Message>%s</Message></Response>", escapeXML(message))
+}
+
+func escapeXML(s string) string {
+	r := strings.NewReplacer(
+		"&", "&amp;",
+		"<", "&lt;",
+		">", "&gt;",
+		`"`, "&quot;",
+		"'", "&apos;",
+	)
+	return r.Replace(s)
+}
@@ -0,0 +1,219 @@
+package twilio
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// computeTestSignature is an independent implementation of Twilio's
+// request-signing algorithm, used as an oracle against validSignature:
+// full request URL, followed by each POST parameter's key immediately
+// followed by its value (sorted by key), HMAC-SHA1'd with the auth token
+// and base64-encoded.
+func computeTestSignature(authToken, fullURL string, form url.Values) string {
+	data := fullURL
+
+	keys := make([]string, 0, len(form))
+	for k := range form {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		for _, v := range form[k] {
+			data += k + v
+		}
+	}
+
+	mac := hmac.New(sha1.New, []byte(authToken))
+	mac.Write([]byte(data))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestValidSignature(t *testing.T) {
+	const token = "test-auth-token"
+	const reqURL = "http://example.com/twilio/sms"
+
+	form := url.Values{
+		"To":         {"+15551234567"},
+		"From":       {"+15557654321"},
+		"Body":       {"hello world"},
+		"MessageSid": {"SMxxxx"},
+	}
+	validSig := computeTestSignature(token, reqURL, form)
+
+	tamperedBody := url.Values{
+		"To":         form["To"],
+		"From":       form["From"],
+		"Body":       {"goodbye"},
+		"MessageSid": form["MessageSid"],
+	}
+
+	tests := []struct {
+		name      string
+		authToken string
+		form      url.Values
+		sig       string
+		setHeader bool
+		want      bool
+	}{
+		{name: "valid signature", authToken: token, form: form, sig: validSig, setHeader: true, want: true},
+		{name: "tampered body", authToken: token, form: tamperedBody, sig: validSig, setHeader: true, want: false},
+		{name: "signed with wrong token", authToken: "some-other-token", form: form, sig: validSig, setHeader: true, want: false},
+		{name: "garbage signature", authToken: token, form: form, sig: "not-a-real-signature", setHeader: true, want: false},
+		{name: "missing signature header", authToken: token, form: form, setHeader: false, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, reqURL, strings.NewReader(tt.form.Encode()))
+			req.Header.Set("Content-Type", contentType)
+			if tt.setHeader {
+				req.Header.Set("X-Twilio-Signature", tt.sig)
+			}
+			if err := req.ParseForm(); err != nil {
+				t.Fatal(err)
+			}
+
+			h := &WebhookHandler{AuthToken: tt.authToken}
+			if got := h.validSignature(req); got != tt.want {
+				t.Errorf("validSignature() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMediaURLs(t *testing.T) {
+	tests := []struct {
+		name string
+		form url.Values
+		want []string
+	}{
+		{name: "no NumMedia", form: url.Values{}, want: nil},
+		{name: "zero media", form: url.Values{"NumMedia": {"0"}}, want: nil},
+		{name: "two attachments", form: url.Values{
+			"NumMedia":  {"2"},
+			"MediaUrl0": {"https://example.com/a.jpg"},
+			"MediaUrl1": {"https://example.com/b.jpg"},
+		}, want: []string{"https://example.com/a.jpg", "https://example.com/b.jpg"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mediaURLs(tt.form)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mediaURLs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// doSignedRequest builds a correctly-signed POST for form and runs it
+// through h, returning the recorded response.
+func doSignedRequest(t *testing.T, h http.Handler, reqURL string, form url.Values, token string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	sig := computeTestSignature(token, reqURL, form)
+	req := httptest.NewRequest(http.MethodPost, reqURL, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("X-Twilio-Signature", sig)
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestServeHTTPDispatchesStatusCallback(t *testing.T) {
+	const token = "test-auth-token"
+
+	var got *StatusCallback
+	inboundCalled := false
+	h := &WebhookHandler{
+		AuthToken:        token,
+		OnStatusCallback: func(sc *StatusCallback) { got = sc },
+		OnInboundSMS:     func(*InboundSMS) { inboundCalled = true },
+	}
+
+	form := url.Values{
+		"MessageSid":    {"SMxxxx"},
+		"MessageStatus": {"delivered"},
+		"To":            {"+15551234567"},
+		"From":          {"+15557654321"},
+		"ErrorCode":     {"0"},
+	}
+	rr := doSignedRequest(t, h, "http://example.com/status", form, token)
+
+	if rr.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d", rr.Code, http.StatusNoContent)
+	}
+	if inboundCalled {
+		t.Fatal("OnInboundSMS should not be called for a status callback")
+	}
+	if got == nil {
+		t.Fatal("OnStatusCallback was not called")
+	}
+	if got.MessageSid != "SMxxxx" || got.MessageStatus != "delivered" || got.ErrorCode != "0" {
+		t.Errorf("got %+v", got)
+	}
+}
+
+func TestServeHTTPDispatchesInboundSMS(t *testing.T) {
+	const token = "test-auth-token"
+
+	var got *InboundSMS
+	statusCalled := false
+	h := &WebhookHandler{
+		AuthToken:        token,
+		OnInboundSMS:     func(sms *InboundSMS) { got = sms },
+		OnStatusCallback: func(*StatusCallback) { statusCalled = true },
+	}
+
+	form := url.Values{
+		"MessageSid": {"SMyyyy"},
+		"To":         {"+15551234567"},
+		"From":       {"+15557654321"},
+		"Body":       {"hi"},
+		"NumMedia":   {"2"},
+		"MediaUrl0":  {"https://example.com/a.jpg"},
+		"MediaUrl1":  {"https://example.com/b.jpg"},
+	}
+	rr := doSignedRequest(t, h, "http://example.com/sms", form, token)
+
+	if rr.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d", rr.Code, http.StatusNoContent)
+	}
+	if statusCalled {
+		t.Fatal("OnStatusCallback should not be called for an inbound message")
+	}
+	if got == nil {
+		t.Fatal("OnInboundSMS was not called")
+	}
+	want := []string{"https://example.com/a.jpg", "https://example.com/b.jpg"}
+	if !reflect.DeepEqual(got.MediaUrls, want) {
+		t.Errorf("MediaUrls = %v, want %v", got.MediaUrls, want)
+	}
+}
+
+func TestServeHTTPRejectsInvalidSignature(t *testing.T) {
+	h := &WebhookHandler{AuthToken: "test-auth-token"}
+
+	form := url.Values{"Body": {"hi"}}
+	req := httptest.NewRequest(http.MethodPost, "http://example.com/sms", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("X-Twilio-Signature", "bogus")
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("status = %d, want %d", rr.Code, http.StatusForbidden)
+	}
+}